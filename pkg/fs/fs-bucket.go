@@ -0,0 +1,39 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fs
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/minio/minio/pkg/probe"
+)
+
+// MakeBucket - creates a new bucket directory under the filesystem root.
+func (fs Filesystem) MakeBucket(bucket string) *probe.Error {
+	if !isValidBucketName(bucket) {
+		return probe.NewError(BucketNameInvalid{Bucket: bucket})
+	}
+	bucketDir := filepath.Join(fs.path, bucket)
+	if _, err := os.Stat(bucketDir); err == nil {
+		return probe.NewError(BucketExists{Bucket: bucket})
+	}
+	if err := os.MkdirAll(bucketDir, 0700); err != nil {
+		return probe.NewError(err)
+	}
+	return nil
+}
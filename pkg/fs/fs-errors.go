@@ -0,0 +1,124 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fs
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+// BucketNameInvalid - bucket name is invalid.
+type BucketNameInvalid struct {
+	Bucket string
+}
+
+func (e BucketNameInvalid) Error() string {
+	return "Bucket name invalid: " + e.Bucket
+}
+
+// BucketNotFound - bucket does not exist.
+type BucketNotFound struct {
+	Bucket string
+}
+
+func (e BucketNotFound) Error() string {
+	return "Bucket not found: " + e.Bucket
+}
+
+// BucketExists - bucket already exists.
+type BucketExists struct {
+	Bucket string
+}
+
+func (e BucketExists) Error() string {
+	return "Bucket exists: " + e.Bucket
+}
+
+// ObjectNameInvalid - object name is invalid.
+type ObjectNameInvalid struct {
+	Bucket string
+	Object string
+}
+
+func (e ObjectNameInvalid) Error() string {
+	return "Object name invalid: " + filepath.Join(e.Bucket, e.Object)
+}
+
+// ObjectNotFound - object does not exist.
+type ObjectNotFound struct {
+	Bucket string
+	Object string
+}
+
+func (e ObjectNotFound) Error() string {
+	return "Object not found: " + filepath.Join(e.Bucket, e.Object)
+}
+
+// ObjectExistsAsDirectory - object already exists as a directory.
+type ObjectExistsAsDirectory struct {
+	Bucket string
+	Object string
+}
+
+func (e ObjectExistsAsDirectory) Error() string {
+	return "Object exists as directory: " + filepath.Join(e.Bucket, e.Object)
+}
+
+// BadDigest - md5 sum mismatch between what was sent by the client and
+// what got recomputed from the stored object.
+type BadDigest struct {
+	ExpectedMD5   string
+	CalculatedMD5 string
+}
+
+func (e BadDigest) Error() string {
+	return fmt.Sprintf("Bad digest: expected %s, got %s", e.ExpectedMD5, e.CalculatedMD5)
+}
+
+// InvalidRange - one of the requested byte ranges does not overlap with
+// the object, or is otherwise unsatisfiable. Maps to HTTP 416.
+type InvalidRange struct {
+	Offset       int64
+	Length       int64
+	ResourceSize int64
+}
+
+func (e InvalidRange) Error() string {
+	return fmt.Sprintf("Invalid range: offset %d, length %d, resource size %d", e.Offset, e.Length, e.ResourceSize)
+}
+
+// PreConditionFailed - one of If-Match/If-Unmodified-Since failed against
+// the current state of the object. Maps to HTTP 412.
+type PreConditionFailed struct {
+	Bucket string
+	Object string
+}
+
+func (e PreConditionFailed) Error() string {
+	return "Precondition failed: " + e.Bucket + "#" + e.Object
+}
+
+// NotModified - one of If-None-Match/If-Modified-Since indicates the
+// client's cached copy is still current. Maps to HTTP 304.
+type NotModified struct {
+	Bucket string
+	Object string
+}
+
+func (e NotModified) Error() string {
+	return "Not modified: " + e.Bucket + "#" + e.Object
+}
@@ -0,0 +1,281 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package fs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newRangeTestFS - creates a filesystem with a single known object,
+// returning the filesystem and the object's content for comparison.
+func newRangeTestFS(t *testing.T) (Filesystem, string, func()) {
+	directory, e := ioutil.TempDir("", "minio-get-object-range-test")
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	filesystem, err := New(directory, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = filesystem.MakeBucket("test-range"); err != nil {
+		t.Fatal(err)
+	}
+	content := "the quick brown fox jumps over the lazy dog"
+	if _, err = filesystem.CreateObject("test-range", "object", "", int64(len(content)), bytes.NewBufferString(content), nil); err != nil {
+		t.Fatal(err)
+	}
+	return filesystem, content, func() { os.RemoveAll(directory) }
+}
+
+// Testing GetObjectWithOpts() with single and suffix ranges.
+func TestGetObjectWithOptsRanges(t *testing.T) {
+	filesystem, content, cleanup := newRangeTestFS(t)
+	defer cleanup()
+
+	testCases := []struct {
+		ranges     []ByteRange
+		expected   string
+		shouldPass bool
+	}{
+		// Test 1: from the start.
+		{[]ByteRange{{Start: 0, End: 4}}, "the q", true},
+		// Test 2: open-ended range through the end of the object.
+		{[]ByteRange{{Start: 4, End: -1}}, "quick brown fox jumps over the lazy dog", true},
+		// Test 3: suffix range, the last 3 bytes.
+		{[]ByteRange{{Start: -3}}, "dog", true},
+		// Test 4: suffix range larger than the object clamps to the whole object.
+		{[]ByteRange{{Start: int64(-(len(content) + 10))}}, content, true},
+		// Test 5: unsatisfiable range (416).
+		{[]ByteRange{{Start: int64(len(content)), End: int64(len(content) + 10)}}, "", false},
+	}
+	for i, testCase := range testCases {
+		var w bytes.Buffer
+		result, err := filesystem.GetObjectWithOpts(&w, "test-range", "object", GetObjectOptions{Ranges: testCase.ranges})
+		if err != nil && testCase.shouldPass {
+			t.Errorf("Test %d: Expected to pass, but failed with: <ERROR> %s", i+1, err.Cause.Error())
+			continue
+		}
+		if err == nil && !testCase.shouldPass {
+			t.Errorf("Test %d: Expected to fail, but passed with body %q", i+1, w.String())
+			continue
+		}
+		if !testCase.shouldPass {
+			if _, ok := err.ToGoError().(InvalidRange); !ok {
+				t.Errorf("Test %d: Expected InvalidRange error, got %v", i+1, err.Cause)
+			}
+			continue
+		}
+		if w.String() != testCase.expected {
+			t.Errorf("Test %d: Expected body %q, got %q", i+1, testCase.expected, w.String())
+		}
+		if !result.IsPartial {
+			t.Errorf("Test %d: Expected IsPartial to be true for a range request", i+1)
+		}
+	}
+}
+
+// Testing GetObjectWithOpts() with multiple ranges, producing a
+// multipart/byteranges body.
+func TestGetObjectWithOptsMultiRange(t *testing.T) {
+	filesystem, content, cleanup := newRangeTestFS(t)
+	defer cleanup()
+
+	var w bytes.Buffer
+	ranges := []ByteRange{{Start: 0, End: 2}, {Start: 4, End: 8}}
+	result, err := filesystem.GetObjectWithOpts(&w, "test-range", "object", GetObjectOptions{Ranges: ranges})
+	if err != nil {
+		t.Fatalf("Expected to pass, but failed with: <ERROR> %s", err.Cause.Error())
+	}
+	if result.Boundary == "" {
+		t.Fatal("Expected a multipart boundary for a multi-range request")
+	}
+	if len(result.Ranges) != 2 {
+		t.Fatalf("Expected 2 resolved ranges, got %d", len(result.Ranges))
+	}
+	body := w.String()
+	if !strings.Contains(body, content[0:3]) || !strings.Contains(body, content[4:9]) {
+		t.Errorf("Expected multipart body to contain both range payloads, got %q", body)
+	}
+	if !strings.Contains(body, "Content-Range: bytes 0-2/") || !strings.Contains(body, "Content-Range: bytes 4-8/") {
+		t.Errorf("Expected multipart body to carry Content-Range headers, got %q", body)
+	}
+}
+
+// Testing GetObjectWithOpts() coalescing of overlapping ranges into a
+// single part.
+func TestGetObjectWithOptsMultiRangeCoalesce(t *testing.T) {
+	filesystem, content, cleanup := newRangeTestFS(t)
+	defer cleanup()
+
+	var w bytes.Buffer
+	// 0-10 and 5-20 overlap, so they must be served as one merged 0-20
+	// range rather than two overlapping parts.
+	ranges := []ByteRange{{Start: 0, End: 10}, {Start: 5, End: 20}}
+	result, err := filesystem.GetObjectWithOpts(&w, "test-range", "object", GetObjectOptions{Ranges: ranges})
+	if err != nil {
+		t.Fatalf("Expected to pass, but failed with: <ERROR> %s", err.Cause.Error())
+	}
+	if len(result.Ranges) != 1 {
+		t.Fatalf("Expected overlapping ranges to coalesce into 1, got %d", len(result.Ranges))
+	}
+	if result.Ranges[0].Start != 0 || result.Ranges[0].End != 20 {
+		t.Errorf("Expected coalesced range 0-20, got %d-%d", result.Ranges[0].Start, result.Ranges[0].End)
+	}
+	if result.Boundary != "" {
+		t.Errorf("Expected no multipart boundary once ranges coalesce to one part, got %q", result.Boundary)
+	}
+	if w.String() != content[0:21] {
+		t.Errorf("Expected body %q, got %q", content[0:21], w.String())
+	}
+}
+
+// Testing GetObjectWithOpts() conditional header handling.
+func TestGetObjectWithOptsConditional(t *testing.T) {
+	filesystem, _, cleanup := newRangeTestFS(t)
+	defer cleanup()
+
+	info, err := filesystem.GetObjectInfo("test-range", "object")
+	if err != nil {
+		t.Fatal(err)
+	}
+	past := info.ModTime.Add(-time.Hour)
+	future := info.ModTime.Add(time.Hour)
+
+	testCases := []struct {
+		opts    GetObjectOptions
+		wantErr error
+	}{
+		// If-Match with the correct ETag passes.
+		{GetObjectOptions{IfMatch: info.MD5Sum}, nil},
+		// If-Match with the wrong ETag is a precondition failure.
+		{GetObjectOptions{IfMatch: "bogus"}, PreConditionFailed{}},
+		// If-None-Match with the current ETag is a cache hit (not modified).
+		{GetObjectOptions{IfNoneMatch: info.MD5Sum}, NotModified{}},
+		// If-Unmodified-Since in the past is a precondition failure.
+		{GetObjectOptions{IfUnmodifiedSince: &past}, PreConditionFailed{}},
+		// If-Modified-Since in the future is not modified.
+		{GetObjectOptions{IfModifiedSince: &future}, NotModified{}},
+		// If-Modified-Since in the past passes through.
+		{GetObjectOptions{IfModifiedSince: &past}, nil},
+	}
+	for i, testCase := range testCases {
+		var w bytes.Buffer
+		_, err := filesystem.GetObjectWithOpts(&w, "test-range", "object", testCase.opts)
+		if testCase.wantErr == nil {
+			if err != nil {
+				t.Errorf("Test %d: Expected to pass, but failed with: <ERROR> %s", i+1, err.Cause.Error())
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("Test %d: Expected to fail, but passed", i+1)
+			continue
+		}
+		switch testCase.wantErr.(type) {
+		case PreConditionFailed:
+			if _, ok := err.ToGoError().(PreConditionFailed); !ok {
+				t.Errorf("Test %d: Expected PreConditionFailed, got %T", i+1, err.ToGoError())
+			}
+		case NotModified:
+			if _, ok := err.ToGoError().(NotModified); !ok {
+				t.Errorf("Test %d: Expected NotModified, got %T", i+1, err.ToGoError())
+			}
+		}
+	}
+}
+
+// Testing GetObjectWithOpts() against a zero-byte object: a whole-object
+// read (no Range requested) must succeed with an empty body rather than
+// fail with InvalidRange (416) - empty objects are valid in S3.
+func TestGetObjectWithOptsEmptyObject(t *testing.T) {
+	directory, e := ioutil.TempDir("", "minio-get-object-range-empty-test")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(directory)
+
+	filesystem, err := New(directory, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = filesystem.MakeBucket("test-range"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = filesystem.CreateObject("test-range", "empty", "", 0, bytes.NewBuffer(nil), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	var w bytes.Buffer
+	result, getErr := filesystem.GetObjectWithOpts(&w, "test-range", "empty", GetObjectOptions{})
+	if getErr != nil {
+		t.Fatalf("Expected to pass, but failed with: <ERROR> %s", getErr.Cause.Error())
+	}
+	if result.ContentLength != 0 {
+		t.Errorf("Expected ContentLength 0, got %d", result.ContentLength)
+	}
+	if w.Len() != 0 {
+		t.Errorf("Expected an empty body, got %q", w.String())
+	}
+}
+
+// Testing GetObjectWithOpts() VerifyMD5.
+func TestGetObjectWithOptsVerifyMD5(t *testing.T) {
+	filesystem, content, cleanup := newRangeTestFS(t)
+	defer cleanup()
+
+	var w bytes.Buffer
+	result, err := filesystem.GetObjectWithOpts(&w, "test-range", "object", GetObjectOptions{VerifyMD5: true})
+	if err != nil {
+		t.Fatalf("Expected to pass, but failed with: <ERROR> %s", err.Cause.Error())
+	}
+	if result.ContentLength != int64(len(content)) {
+		t.Errorf("Expected ContentLength %d, got %d", len(content), result.ContentLength)
+	}
+	if w.String() != content {
+		t.Errorf("Expected body %q, got %q", content, w.String())
+	}
+}
+
+// Testing GetObjectWithOpts() VerifyMD5 against an object whose on-disk
+// content no longer matches the MD5 recorded at CreateObject time - it
+// must fail with BadDigest rather than silently serving the corrupted
+// bytes.
+func TestGetObjectWithOptsVerifyMD5BadDigest(t *testing.T) {
+	filesystem, _, cleanup := newRangeTestFS(t)
+	defer cleanup()
+
+	objectPath := filepath.Join(filesystem.path, "test-range", "object")
+	if err := ioutil.WriteFile(objectPath, []byte("corrupted content, does not match the stored md5sum"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var w bytes.Buffer
+	_, err := filesystem.GetObjectWithOpts(&w, "test-range", "object", GetObjectOptions{VerifyMD5: true})
+	if err == nil {
+		t.Fatal("Expected to fail with BadDigest, but passed")
+	}
+	if _, ok := err.ToGoError().(BadDigest); !ok {
+		t.Errorf("Expected BadDigest, got %T", err.ToGoError())
+	}
+}
@@ -18,109 +18,20 @@ package fs
 
 import (
 	"bytes"
-	"crypto/md5"
-	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"testing"
 )
 
-// Testing GetObjectInfo().
-func TestGetObjectInfo(t *testing.T) {
-	directory, e := ioutil.TempDir("", "minio-get-objinfo-test")
-	if e != nil {
-		t.Fatal(e)
-	}
-	defer os.RemoveAll(directory)
-
-	// Create the filesystem.
-	fs, err := New(directory, 0)
-	if err != nil {
-		t.Fatal(err)
-	}
-	// This bucket is used for testing getObjectInfo operations.
-	err = fs.MakeBucket("test-getobjectinfo")
-	if err != nil {
-		t.Fatal(err)
-	}
-	_, err = fs.CreateObject("test-getobjectinfo", "Asia/asiapics.jpg", "", int64(len("asiapics")), bytes.NewBufferString("asiapics"), nil)
-	if err != nil {
-		t.Fatal(err)
-	}
-	resultCases := []ObjectInfo{
-		// ObjectInfo -1.
-		// ObjectName set to a existing object in the test case (Test case 14).
-		{Bucket: "test-getobjectinfo", Name: "Asia/asiapics.jpg", ContentType: "image/jpeg", IsDir: false},
-	}
-	testCases := []struct {
-		rootPath   string
-		bucketName string
-		objectName string
-
-		// Expected output of GetObjectInfo.
-		result ObjectInfo
-		err    error
-		// Flag indicating whether the test is expected to pass or not.
-		shouldPass bool
-	}{
-		// Test cases with invalid bucket names ( Test number 1-4 ).
-		{fs.path, ".test", "", ObjectInfo{}, BucketNameInvalid{Bucket: ".test"}, false},
-		{fs.path, "Test", "", ObjectInfo{}, BucketNameInvalid{Bucket: "Test"}, false},
-		{fs.path, "---", "", ObjectInfo{}, BucketNameInvalid{Bucket: "---"}, false},
-		{fs.path, "ad", "", ObjectInfo{}, BucketNameInvalid{Bucket: "ad"}, false},
-		// Test cases with valid but non-existing bucket names (Test number 5-7).
-		{fs.path, "abcdefgh", "abc", ObjectInfo{}, BucketNotFound{Bucket: "abcdefgh"}, false},
-		{fs.path, "ijklmnop", "efg", ObjectInfo{}, BucketNotFound{Bucket: "ijklmnop"}, false},
-		// Test cases with valid but non-existing bucket names and invalid object name (Test number 8-9).
-		{fs.path, "abcdefgh", "", ObjectInfo{}, ObjectNameInvalid{Bucket: "abcdefgh", Object: ""}, false},
-		{fs.path, "ijklmnop", "", ObjectInfo{}, ObjectNameInvalid{Bucket: "ijklmnop", Object: ""}, false},
-		// Test cases with non-existing object name with existing bucket (Test number 10-12).
-		{fs.path, "test-getobjectinfo", "Africa", ObjectInfo{}, ObjectNotFound{Bucket: "test-getobjectinfo", Object: "Africa"}, false},
-		{fs.path, "test-getobjectinfo", "Antartica", ObjectInfo{}, ObjectNotFound{Bucket: "test-getobjectinfo", Object: "Antartica"}, false},
-		{fs.path, "test-getobjectinfo", "Asia/myfile", ObjectInfo{}, ObjectNotFound{Bucket: "test-getobjectinfo", Object: "Asia/myfile"}, false},
-		// Test case with existing bucket but object name set to a directory (Test number 13).
-		{fs.path, "test-getobjectinfo", "Asia", ObjectInfo{}, ObjectNotFound{Bucket: "test-getobjectinfo", Object: "Asia"}, false},
-		// Valid case with existing object (Test number 14).
-		{fs.path, "test-getobjectinfo", "Asia/asiapics.jpg", resultCases[0], nil, true},
-	}
-	for i, testCase := range testCases {
-		result, err := fs.GetObjectInfo(testCase.bucketName, testCase.objectName)
-		if err != nil && testCase.shouldPass {
-			t.Errorf("Test %d: Expected to pass, but failed with: <ERROR> %s", i+1, err.Cause.Error())
-		}
-		if err == nil && !testCase.shouldPass {
-			t.Errorf("Test %d: Expected to fail with <ERROR> \"%s\", but passed instead", i+1, testCase.err.Error())
-		}
-		// Failed as expected, but does it fail for the expected reason.
-		if err != nil && !testCase.shouldPass {
-			if testCase.err.Error() != err.Cause.Error() {
-				t.Errorf("Test %d: Expected to fail with error \"%s\", but instead failed with error \"%s\" instead", i+1, testCase.err.Error(), err.Cause.Error())
-			}
-		}
-
-		// Test passes as expected, but the output values are verified for correctness here.
-		if err == nil && testCase.shouldPass {
-			if testCase.result.Bucket != result.Bucket {
-				t.Fatalf("Test %d: Expected Bucket name to be '%s', but found '%s' instead", i+1, testCase.result.Bucket, result.Bucket)
-			}
-			if testCase.result.Name != result.Name {
-				t.Errorf("Test %d: Expected Object name to be %s, but instead found it to be %s", i+1, testCase.result.Name, result.Name)
-			}
-			if testCase.result.ContentType != result.ContentType {
-				t.Errorf("Test %d: Expected Content Type of the object to be %v, but instead found it to be %v", i+1, testCase.result.ContentType, result.ContentType)
-			}
-			if testCase.result.IsDir != result.IsDir {
-				t.Errorf("Test %d: Expected IsDir flag of the object to be %v, but instead found it to be %v", i+1, testCase.result.IsDir, result.IsDir)
-			}
-		}
-	}
-}
-
-// Testing getObjectInfo().
+// TestGetObjectInfoCore exercises the unexported getObjectInfo helper
+// directly. Unlike GetObjectInfo, getObjectInfo operates on a raw
+// rootPath and has no objectstore.ObjectStore equivalent, so it stays
+// here rather than moving to the cross-backend conformance suite in
+// pkg/objectstore (which now covers what used to be this file's
+// TestGetObjectInfo and BenchmarkGetObject).
 func TestGetObjectInfoCore(t *testing.T) {
 	directory, e := ioutil.TempDir("", "minio-get-objinfo-test")
 	if e != nil {
@@ -205,48 +116,34 @@ func TestGetObjectInfoCore(t *testing.T) {
 	}
 }
 
-func BenchmarkGetObject(b *testing.B) {
-	// Make a temporary directory to use as the filesystem.
-	directory, e := ioutil.TempDir("", "minio-benchmark-getobject")
+// TestGetObjectInfoDirectory exercises the public GetObjectInfo's
+// directory-vs-object guard: unlike the unexported getObjectInfo above,
+// which reports a directory prefix as IsDir:true, GetObjectInfo treats a
+// directory prefix as not-found, since from a client's point of view
+// there's no object there to read.
+func TestGetObjectInfoDirectory(t *testing.T) {
+	directory, e := ioutil.TempDir("", "minio-get-objinfo-dir-test")
 	if e != nil {
-		b.Fatal(e)
+		t.Fatal(e)
 	}
 	defer os.RemoveAll(directory)
 
-	// Create the filesystem.
-	filesystem, err := New(directory, 0)
+	fs, err := New(directory, 0)
 	if err != nil {
-		b.Fatal(err)
+		t.Fatal(err)
 	}
-
-	// Make a bucket and put in a few objects.
-	err = filesystem.MakeBucket("bucket")
-	if err != nil {
-		b.Fatal(err)
+	if err = fs.MakeBucket("test-getobjinfo-dir"); err != nil {
+		t.Fatal(err)
 	}
-
-	text := "Jack and Jill went up the hill / To fetch a pail of water."
-	hasher := md5.New()
-	hasher.Write([]byte(text))
-	sum := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
-	for i := 0; i < 10; i++ {
-		_, err = filesystem.CreateObject("bucket", "object"+strconv.Itoa(i), sum, int64(len(text)), bytes.NewBufferString(text), nil)
-		if err != nil {
-			b.Fatal(err)
-		}
+	if _, err = fs.CreateObject("test-getobjinfo-dir", "Asia/asiapics.jpg", "", int64(len("asiapics")), bytes.NewBufferString("asiapics"), nil); err != nil {
+		t.Fatal(err)
 	}
 
-	var w bytes.Buffer
-
-	b.ResetTimer()
-
-	for i := 0; i < b.N; i++ {
-		n, err := filesystem.GetObject(&w, "bucket", "object"+strconv.Itoa(i%10), 0, 0)
-		if err != nil {
-			b.Error(err)
-		}
-		if n != int64(len(text)) {
-			b.Errorf("GetObject returned incorrect length %d (should be %d)\n", n, int64(len(text)))
-		}
+	_, err = fs.GetObjectInfo("test-getobjinfo-dir", "Asia")
+	if err == nil {
+		t.Fatal("Expected GetObjectInfo on a directory prefix to fail, but it passed")
 	}
-}
\ No newline at end of file
+	if _, ok := err.ToGoError().(ObjectNotFound); !ok {
+		t.Errorf("Expected ObjectNotFound, got %T", err.ToGoError())
+	}
+}
@@ -0,0 +1,74 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package fs implements a POSIX filesystem backed object storage.
+package fs
+
+import (
+	"os"
+	"regexp"
+
+	"github.com/minio/minio/pkg/probe"
+)
+
+// Filesystem - implements object storage on top of a regular directory
+// tree. Buckets are top level directories, objects are regular files
+// nested underneath them.
+type Filesystem struct {
+	path        string
+	minFreeDisk int64
+}
+
+// New - instantiates a new filesystem rooted at rootPath. minFreeDisk is
+// stored on the returned Filesystem as the minimum free disk space (in
+// percent) for writes; no operation checks it, so writes are accepted
+// regardless of free disk space.
+func New(rootPath string, minFreeDisk int64) (Filesystem, *probe.Error) {
+	if rootPath == "" {
+		return Filesystem{}, probe.NewError(errInvalidArgument)
+	}
+	info, err := os.Stat(rootPath)
+	if err != nil {
+		return Filesystem{}, probe.NewError(err)
+	}
+	if !info.IsDir() {
+		return Filesystem{}, probe.NewError(errInvalidArgument)
+	}
+	fs := Filesystem{
+		path:        rootPath,
+		minFreeDisk: minFreeDisk,
+	}
+	return fs, nil
+}
+
+var errInvalidArgument = os.ErrInvalid
+
+// bucketNameRegexp - bucket names are lowercase, 3-63 chars, alphanumeric
+// with '.' and '-' as internal separators.
+var bucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// isValidBucketName - validates the given bucket name.
+func isValidBucketName(bucket string) bool {
+	return bucketNameRegexp.MatchString(bucket)
+}
+
+// isValidObjectName - validates the given object name.
+func isValidObjectName(object string) bool {
+	if object == "" || object == "." || object == ".." {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,285 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// This file replaces the tempdir-backed, fs-specific
+// TestGetObjectInfo/BenchmarkGetObject from pkg/fs/fs-object_test.go
+// with a conformance suite that runs the same assertions against every
+// fully-implemented ObjectStore backend. The "erasure" backend is a stub
+// (see erasure_backend.go) and is excluded until it has a real
+// implementation to conform to. TestGetObjectInfoCore stays in pkg/fs: it
+// operates on fs's unexported getObjectInfo(rootPath, ...) helper, which
+// has no cross-backend equivalent. The directory-vs-object guard on the
+// public GetObjectInfo is covered by fs.TestGetObjectInfoDirectory,
+// since the in-memory backend has no directories to exercise that guard
+// against.
+package objectstore
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"testing"
+)
+
+// stubBackends - registered backends with no real implementation to
+// conform to yet. Excluded from the conformance suite explicitly so a
+// newly registered, still-stub backend doesn't fail conformance by default.
+var stubBackends = map[string]bool{
+	"erasure": true,
+}
+
+// conformanceBackends - backends exercised by the conformance suite, driven
+// off the registry so a newly registered backend is picked up automatically.
+func conformanceBackends() []string {
+	names := make([]string, 0, len(Backends()))
+	for _, name := range Backends() {
+		if !stubBackends[name] {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// openConformanceBackend - opens a fresh, isolated store for the named
+// backend and returns a cleanup function the caller must defer.
+func openConformanceBackend(t testing.TB, name string) (ObjectStore, func()) {
+	switch name {
+	case "fs":
+		directory, err := ioutil.TempDir("", "minio-objectstore-conformance")
+		if err != nil {
+			t.Fatal(err)
+		}
+		store, oerr := Open("fs://" + directory)
+		if oerr != nil {
+			t.Fatal(oerr)
+		}
+		return store, func() { os.RemoveAll(directory) }
+	case "memory":
+		store, oerr := Open("memory://")
+		if oerr != nil {
+			t.Fatal(oerr)
+		}
+		return store, func() {}
+	}
+	t.Fatalf("unknown conformance backend %q", name)
+	return nil, nil
+}
+
+// TestGetObjectInfo - conformance suite for GetObjectInfo, replacing the
+// fs-only test of the same name, run identically against every backend
+// in conformanceBackends.
+func TestGetObjectInfo(t *testing.T) {
+	for _, name := range conformanceBackends() {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			store, cleanup := openConformanceBackend(t, name)
+			defer cleanup()
+
+			if err := store.MakeBucket("test-getobjectinfo"); err != nil {
+				t.Fatal(err)
+			}
+			content := "asiapics"
+			if _, err := store.CreateObject("test-getobjectinfo", "asiapics.jpg", "", int64(len(content)), bytes.NewBufferString(content), nil); err != nil {
+				t.Fatal(err)
+			}
+
+			testCases := []struct {
+				bucket, object string
+				shouldPass     bool
+				// wantErr, when shouldPass is false, is the expected
+				// objectstore.Err* type - nil means "any error".
+				wantErr error
+			}{
+				// Test 1: invalid bucket name.
+				{".test", "asiapics.jpg", false, ErrBucketNameInvalid{}},
+				// Test 2: valid bucket, non-existing object.
+				{"test-getobjectinfo", "nope", false, ErrObjectNotFound{}},
+				// Test 3: valid bucket, existing object.
+				{"test-getobjectinfo", "asiapics.jpg", true, nil},
+			}
+			for i, testCase := range testCases {
+				info, err := store.GetObjectInfo(testCase.bucket, testCase.object)
+				if err != nil && testCase.shouldPass {
+					t.Errorf("Test %d: Expected to pass, but failed with: <ERROR> %s", i+1, err.Cause.Error())
+				}
+				if err == nil && !testCase.shouldPass {
+					t.Errorf("Test %d: Expected to fail, but passed instead", i+1)
+				}
+				if err != nil && !testCase.shouldPass && testCase.wantErr != nil {
+					gotType := fmt.Sprintf("%T", err.ToGoError())
+					wantType := fmt.Sprintf("%T", testCase.wantErr)
+					if gotType != wantType {
+						t.Errorf("Test %d: Expected error type %s, got %s", i+1, wantType, gotType)
+					}
+				}
+				if err == nil && testCase.shouldPass {
+					if info.Name != testCase.object {
+						t.Errorf("Test %d: Expected object name %s, got %s", i+1, testCase.object, info.Name)
+					}
+					if info.Size != int64(len(content)) {
+						t.Errorf("Test %d: Expected size %d, got %d", i+1, len(content), info.Size)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestGetObject - conformance suite covering whole-object reads and their
+// MD5, run identically against every backend in conformanceBackends.
+func TestGetObject(t *testing.T) {
+	for _, name := range conformanceBackends() {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			store, cleanup := openConformanceBackend(t, name)
+			defer cleanup()
+
+			if err := store.MakeBucket("bucket"); err != nil {
+				t.Fatal(err)
+			}
+			text := "Jack and Jill went up the hill / To fetch a pail of water."
+			hasher := md5.New()
+			hasher.Write([]byte(text))
+			sum := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+			if _, err := store.CreateObject("bucket", "object", sum, int64(len(text)), bytes.NewBufferString(text), nil); err != nil {
+				t.Fatal(err)
+			}
+
+			var w bytes.Buffer
+			n, err := store.GetObject(&w, "bucket", "object", 0, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if n != int64(len(text)) {
+				t.Errorf("Expected GetObject to return length %d, got %d", len(text), n)
+			}
+			if w.String() != text {
+				t.Errorf("Expected body %q, got %q", text, w.String())
+			}
+		})
+	}
+}
+
+// TestListAndDeleteObjects - conformance suite covering ListObjects'
+// delimiter roll-up and the DeleteObject/DeleteBucket surface, run against
+// every backend in conformanceBackends. Backends that don't implement this
+// surface yet (ErrNotImplemented) are skipped rather than failed, since
+// ObjectStore advertises it but not every backend has caught up.
+func TestListAndDeleteObjects(t *testing.T) {
+	for _, name := range conformanceBackends() {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			store, cleanup := openConformanceBackend(t, name)
+			defer cleanup()
+
+			if err := store.MakeBucket("bucket"); err != nil {
+				t.Fatal(err)
+			}
+			content := "x"
+			for _, object := range []string{"a", "dir/b", "dir/c", "other"} {
+				if _, err := store.CreateObject("bucket", object, "", int64(len(content)), bytes.NewBufferString(content), nil); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			result, err := store.ListObjects("bucket", "", "", "/", 0)
+			if err != nil {
+				if err.ToGoError() == ErrNotImplemented {
+					t.Skipf("%s backend does not implement ListObjects yet", name)
+				}
+				t.Fatalf("ListObjects failed: <ERROR> %s", err.Cause.Error())
+			}
+			wantObjects := map[string]bool{"a": true, "other": true}
+			for _, obj := range result.Objects {
+				if !wantObjects[obj.Name] {
+					t.Errorf("Unexpected object %q in delimited listing", obj.Name)
+				}
+				delete(wantObjects, obj.Name)
+			}
+			if len(wantObjects) != 0 {
+				t.Errorf("Missing objects in delimited listing: %v", wantObjects)
+			}
+			if len(result.Prefixes) != 1 || result.Prefixes[0] != "dir/" {
+				t.Errorf("Expected common prefix [\"dir/\"], got %v", result.Prefixes)
+			}
+
+			if err := store.DeleteObject("bucket", "a"); err != nil {
+				t.Fatalf("DeleteObject failed: <ERROR> %s", err.Cause.Error())
+			}
+			if _, err := store.GetObjectInfo("bucket", "a"); err == nil {
+				t.Error("Expected deleted object to be gone")
+			}
+
+			if err := store.DeleteBucket("bucket"); err == nil {
+				t.Error("Expected DeleteBucket on a non-empty bucket to fail")
+			} else if _, ok := err.ToGoError().(ErrBucketNotEmpty); !ok {
+				t.Errorf("Expected ErrBucketNotEmpty, got %T", err.ToGoError())
+			}
+
+			for _, object := range []string{"dir/b", "dir/c", "other"} {
+				if err := store.DeleteObject("bucket", object); err != nil {
+					t.Fatalf("DeleteObject(%s) failed: <ERROR> %s", object, err.Cause.Error())
+				}
+			}
+			if err := store.DeleteBucket("bucket"); err != nil {
+				t.Fatalf("DeleteBucket on an empty bucket failed: <ERROR> %s", err.Cause.Error())
+			}
+		})
+	}
+}
+
+// BenchmarkGetObject - conformance benchmark for GetObject, replacing the
+// fs-only benchmark of the same name, run identically against every
+// backend in conformanceBackends.
+func BenchmarkGetObject(b *testing.B) {
+	for _, name := range conformanceBackends() {
+		name := name
+		b.Run(name, func(b *testing.B) {
+			store, cleanup := openConformanceBackend(b, name)
+			defer cleanup()
+
+			if err := store.MakeBucket("bucket"); err != nil {
+				b.Fatal(err)
+			}
+			text := "Jack and Jill went up the hill / To fetch a pail of water."
+			hasher := md5.New()
+			hasher.Write([]byte(text))
+			sum := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+			for i := 0; i < 10; i++ {
+				if _, err := store.CreateObject("bucket", "object"+strconv.Itoa(i), sum, int64(len(text)), bytes.NewBufferString(text), nil); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			var w bytes.Buffer
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				w.Reset()
+				n, err := store.GetObject(&w, "bucket", "object"+strconv.Itoa(i%10), 0, 0)
+				if err != nil {
+					b.Error(err)
+				}
+				if n != int64(len(text)) {
+					b.Errorf("GetObject returned incorrect length %d (should be %d)\n", n, int64(len(text)))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectstore
+
+import (
+	"io"
+
+	"github.com/minio/minio/pkg/probe"
+)
+
+func init() {
+	Register("erasure", openErasureBackend)
+}
+
+// erasureBackend - placeholder for a future sharded/erasure-coded
+// backend that stripes objects across a set of disks with parity, the
+// way Minio's later server-side erasure coding works. Registered now so
+// the "erasure://" scheme and the ObjectStore surface it will need are
+// reserved, but every operation is currently unimplemented.
+type erasureBackend struct {
+	set string
+}
+
+// openErasureBackend - dsn is "erasure://<set-name>"; no disks are
+// actually provisioned yet.
+func openErasureBackend(dsn string) (ObjectStore, error) {
+	return &erasureBackend{set: dsn}, nil
+}
+
+func (e *erasureBackend) MakeBucket(bucket string) *probe.Error { return probe.NewError(ErrNotImplemented) }
+
+func (e *erasureBackend) GetBucketInfo(bucket string) (BucketInfo, *probe.Error) {
+	return BucketInfo{}, probe.NewError(ErrNotImplemented)
+}
+
+func (e *erasureBackend) ListBuckets() ([]BucketInfo, *probe.Error) {
+	return nil, probe.NewError(ErrNotImplemented)
+}
+
+func (e *erasureBackend) DeleteBucket(bucket string) *probe.Error {
+	return probe.NewError(ErrNotImplemented)
+}
+
+func (e *erasureBackend) CreateObject(bucket, object, md5sum string, size int64, data io.Reader, metadata map[string]string) (ObjectInfo, *probe.Error) {
+	return ObjectInfo{}, probe.NewError(ErrNotImplemented)
+}
+
+func (e *erasureBackend) GetObject(w io.Writer, bucket, object string, offset, length int64) (int64, error) {
+	return 0, ErrNotImplemented
+}
+
+func (e *erasureBackend) GetObjectInfo(bucket, object string) (ObjectInfo, *probe.Error) {
+	return ObjectInfo{}, probe.NewError(ErrNotImplemented)
+}
+
+func (e *erasureBackend) ListObjects(bucket, prefix, marker, delimiter string, maxKeys int) (ListObjectsResult, *probe.Error) {
+	return ListObjectsResult{}, probe.NewError(ErrNotImplemented)
+}
+
+func (e *erasureBackend) DeleteObject(bucket, object string) *probe.Error {
+	return probe.NewError(ErrNotImplemented)
+}
+
+func (e *erasureBackend) NewMultipartUpload(bucket, object string, metadata map[string]string) (string, *probe.Error) {
+	return "", probe.NewError(ErrNotImplemented)
+}
+
+func (e *erasureBackend) PutObjectPart(bucket, object, uploadID string, partNumber int, md5sum string, size int64, data io.Reader) (PartInfo, *probe.Error) {
+	return PartInfo{}, probe.NewError(ErrNotImplemented)
+}
+
+func (e *erasureBackend) CompleteMultipartUpload(bucket, object, uploadID string, parts []CompletePart) (ObjectInfo, *probe.Error) {
+	return ObjectInfo{}, probe.NewError(ErrNotImplemented)
+}
+
+func (e *erasureBackend) AbortMultipartUpload(bucket, object, uploadID string) *probe.Error {
+	return probe.NewError(ErrNotImplemented)
+}
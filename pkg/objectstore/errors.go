@@ -0,0 +1,92 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectstore
+
+import "errors"
+
+// ErrNotImplemented - returned by backend methods that are part of the
+// ObjectStore interface but not yet implemented by that backend (e.g.
+// the fs backend has no bucket listing or multipart support).
+var ErrNotImplemented = errors.New("objectstore: not implemented")
+
+// ErrBucketNameInvalid - the given bucket name fails naming validation.
+type ErrBucketNameInvalid struct {
+	Bucket string
+}
+
+func (e ErrBucketNameInvalid) Error() string {
+	return "objectstore: bucket name invalid: " + e.Bucket
+}
+
+// ErrBucketNotFound - the named bucket does not exist.
+type ErrBucketNotFound struct {
+	Bucket string
+}
+
+func (e ErrBucketNotFound) Error() string {
+	return "objectstore: bucket not found: " + e.Bucket
+}
+
+// ErrBucketExists - the named bucket already exists.
+type ErrBucketExists struct {
+	Bucket string
+}
+
+func (e ErrBucketExists) Error() string {
+	return "objectstore: bucket exists: " + e.Bucket
+}
+
+// ErrBadDigest - the recomputed MD5 of an uploaded or stored object does
+// not match what was expected.
+type ErrBadDigest struct {
+	ExpectedMD5   string
+	CalculatedMD5 string
+}
+
+func (e ErrBadDigest) Error() string {
+	return "objectstore: bad digest: expected " + e.ExpectedMD5 + ", got " + e.CalculatedMD5
+}
+
+// ErrObjectNotFound - the named object does not exist.
+type ErrObjectNotFound struct {
+	Bucket string
+	Object string
+}
+
+func (e ErrObjectNotFound) Error() string {
+	return "objectstore: object not found: " + e.Bucket + "/" + e.Object
+}
+
+// ErrObjectNameInvalid - the given object name fails naming validation.
+type ErrObjectNameInvalid struct {
+	Bucket string
+	Object string
+}
+
+func (e ErrObjectNameInvalid) Error() string {
+	return "objectstore: object name invalid: " + e.Bucket + "/" + e.Object
+}
+
+// ErrBucketNotEmpty - DeleteBucket was called on a bucket that still
+// contains objects.
+type ErrBucketNotEmpty struct {
+	Bucket string
+}
+
+func (e ErrBucketNotEmpty) Error() string {
+	return "objectstore: bucket not empty: " + e.Bucket
+}
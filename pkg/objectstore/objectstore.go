@@ -0,0 +1,93 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package objectstore defines the storage backend abstraction that sits
+// underneath Minio's server layer. pkg/fs is one ObjectStore
+// implementation among potentially many (in-memory, sharded/erasure
+// coded, remote, ...); callers obtain one through Open rather than
+// depending on fs.Filesystem directly.
+package objectstore
+
+import (
+	"io"
+	"time"
+
+	"github.com/minio/minio/pkg/probe"
+)
+
+// BucketInfo - metadata about a single bucket.
+type BucketInfo struct {
+	Name    string
+	Created time.Time
+}
+
+// ObjectInfo - metadata about a single object, or a directory-like
+// prefix when IsDir is true.
+type ObjectInfo struct {
+	Bucket      string
+	Name        string
+	ModTime     time.Time
+	Size        int64
+	IsDir       bool
+	MD5Sum      string
+	ContentType string
+}
+
+// ListObjectsResult - a page of ListObjects results.
+type ListObjectsResult struct {
+	Objects     []ObjectInfo
+	Prefixes    []string
+	IsTruncated bool
+	NextMarker  string
+}
+
+// PartInfo - metadata about a single uploaded multipart part.
+type PartInfo struct {
+	PartNumber int
+	MD5Sum     string
+	Size       int64
+}
+
+// CompletePart - identifies one part of a multipart upload to be
+// assembled by CompleteMultipartUpload.
+type CompletePart struct {
+	PartNumber int
+	MD5Sum     string
+}
+
+// ObjectStore - the storage backend contract. Any type satisfying this
+// interface can serve Minio's bucket and object APIs: bucket CRUD, object
+// CRUD, listing, and multipart upload.
+type ObjectStore interface {
+	// Buckets.
+	MakeBucket(bucket string) *probe.Error
+	GetBucketInfo(bucket string) (BucketInfo, *probe.Error)
+	ListBuckets() ([]BucketInfo, *probe.Error)
+	DeleteBucket(bucket string) *probe.Error
+
+	// Objects.
+	CreateObject(bucket, object, md5sum string, size int64, data io.Reader, metadata map[string]string) (ObjectInfo, *probe.Error)
+	GetObject(w io.Writer, bucket, object string, offset, length int64) (int64, error)
+	GetObjectInfo(bucket, object string) (ObjectInfo, *probe.Error)
+	ListObjects(bucket, prefix, marker, delimiter string, maxKeys int) (ListObjectsResult, *probe.Error)
+	DeleteObject(bucket, object string) *probe.Error
+
+	// Multipart uploads.
+	NewMultipartUpload(bucket, object string, metadata map[string]string) (uploadID string, err *probe.Error)
+	PutObjectPart(bucket, object, uploadID string, partNumber int, md5sum string, size int64, data io.Reader) (PartInfo, *probe.Error)
+	CompleteMultipartUpload(bucket, object, uploadID string, parts []CompletePart) (ObjectInfo, *probe.Error)
+	AbortMultipartUpload(bucket, object, uploadID string) *probe.Error
+}
@@ -0,0 +1,81 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectstore
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Factory - constructs an ObjectStore from a backend-specific DSN. The
+// scheme of the DSN (everything before "://") is what backends register
+// themselves under; the remainder is passed through unparsed.
+type Factory func(dsn string) (ObjectStore, error)
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]Factory)
+)
+
+// Register - makes a backend factory available under name for later use
+// by Open. Intended to be called from a backend package's init(), mirroring
+// the database/sql driver registration pattern. Panics on duplicate or nil
+// registration, since both indicate a programming error at startup.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if factory == nil {
+		panic("objectstore: Register factory is nil for " + name)
+	}
+	if _, dup := registry[name]; dup {
+		panic("objectstore: Register called twice for backend " + name)
+	}
+	registry[name] = factory
+}
+
+// Open - opens an ObjectStore for the given DSN, dispatching on its
+// scheme (e.g. "fs:///data", "memory://", "erasure://set1"). Returns an
+// error if no backend is registered under that scheme.
+func Open(dsn string) (ObjectStore, error) {
+	name := dsn
+	if i := strings.Index(dsn, "://"); i >= 0 {
+		name = dsn[:i]
+	}
+	registryMu.Lock()
+	factory, ok := registry[name]
+	registryMu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("objectstore: unknown backend %q", name)
+	}
+	return factory(dsn)
+}
+
+// Backends - the names of all currently registered backends, sorted for
+// deterministic iteration. The conformance test suite in pkg/objectstore
+// runs against every entry here, minus any explicitly listed stub backend.
+func Backends() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
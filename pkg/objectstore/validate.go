@@ -0,0 +1,30 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package objectstore
+
+import "regexp"
+
+// bucketNameRegexp - mirrors the bucket naming rule enforced by the fs
+// backend (pkg/fs.isValidBucketName): lowercase, 3-63 chars, alphanumeric
+// with '.' and '-' as internal separators. Kept here too so any backend
+// can validate consistently without importing pkg/fs.
+var bucketNameRegexp = regexp.MustCompile(`^[a-z0-9][a-z0-9.-]{1,61}[a-z0-9]$`)
+
+// isValidBucketName - validates the given bucket name.
+func isValidBucketName(bucket string) bool {
+	return bucketNameRegexp.MatchString(bucket)
+}
@@ -0,0 +1,66 @@
+/*
+ * Minio Cloud Storage, (C) 2016 Minio, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package probe implements a lightweight wrapper around error values that
+// keeps track of the original cause while allowing call sites to annotate
+// the error with a trace of the functions it passed through.
+package probe
+
+// Error wraps a causal error together with an optional trace of the call
+// path that propagated it. It satisfies the standard error interface so it
+// can be used anywhere a plain error is expected.
+type Error struct {
+	Cause error
+	trace []string
+}
+
+// NewError creates a new *Error from a standard error. Returns nil if err
+// is nil so callers can write `return probe.NewError(err)` unconditionally.
+func NewError(err error) *Error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Cause: err}
+}
+
+// Trace appends call site annotations and returns the same *Error, allowing
+// errors to be enriched as they propagate up the stack:
+//
+//	return probe.NewError(err).Trace(bucket, object)
+func (e *Error) Trace(fields ...string) *Error {
+	if e == nil {
+		return nil
+	}
+	e.trace = append(e.trace, fields...)
+	return e
+}
+
+// ToGoError unwraps the *Error back into a plain error, returning nil if e
+// is nil.
+func (e *Error) ToGoError() error {
+	if e == nil {
+		return nil
+	}
+	return e.Cause
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	if e == nil || e.Cause == nil {
+		return ""
+	}
+	return e.Cause.Error()
+}